@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tomoconnor/metalink/internal/cache"
+	"github.com/tomoconnor/metalink/internal/fetch"
+	"github.com/tomoconnor/metalink/internal/handlers"
+)
+
+// defaultBatchWorkers and maxBatchSize bound POST /metadata/batch: how many
+// URLs are resolved concurrently, and how many a single request may submit.
+const (
+	defaultBatchWorkers = 8
+	maxBatchSize        = 50
+)
+
+// batchRequest is the body of POST /metadata/batch.
+type batchRequest struct {
+	URLs []string `json:"urls"`
+}
+
+// getBatchMetadataHandler returns a handler that resolves many URLs
+// concurrently, bounded by METALINK_BATCH_WORKERS workers, and reports a
+// per-URL result or error. A client disconnect cancels any fetches still
+// in flight.
+func getBatchMetadataHandler(registry *handlers.Registry, fetcher *fetch.Fetcher, metaCache *cache.Cache) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req batchRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		}
+		if len(req.URLs) > maxBatchSize {
+			return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{
+				"error": "too many urls: max " + strconv.Itoa(maxBatchSize) + " per request",
+			})
+		}
+
+		g, ctx := errgroup.WithContext(c.Request().Context())
+		sem := make(chan struct{}, batchWorkersFromEnv())
+
+		results := make(map[string]any, len(req.URLs))
+		var mu sync.Mutex
+
+		for _, rawURL := range req.URLs {
+			rawURL := rawURL
+			g.Go(func() error {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+
+				result := resolveBatchURL(ctx, registry, fetcher, metaCache, rawURL)
+
+				mu.Lock()
+				results[rawURL] = result
+				mu.Unlock()
+				return nil
+			})
+		}
+		// Per-URL failures are recorded in results rather than returned, so
+		// the only error g.Wait() can surface is context cancellation
+		// (client disconnect or request deadline).
+		if err := g.Wait(); err != nil {
+			return c.JSON(http.StatusBadGateway, map[string]string{"error": "batch request cancelled"})
+		}
+
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// resolveBatchURL resolves a single URL for the batch endpoint, returning
+// either its metadata or a JSON-friendly error object.
+func resolveBatchURL(ctx context.Context, registry *handlers.Registry, fetcher *fetch.Fetcher, metaCache *cache.Cache, rawURL string) any {
+	parsedURL, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return map[string]string{"error": "invalid url"}
+	}
+
+	meta, err := metaCache.GetOrFetch(ctx, rawURL, func(ctx context.Context) (any, error) {
+		return resolveMetadata(ctx, registry, fetcher, parsedURL)
+	})
+	if err != nil {
+		return map[string]string{"error": "failed to fetch target"}
+	}
+	return meta
+}
+
+// batchWorkersFromEnv reads METALINK_BATCH_WORKERS as a worker pool size,
+// falling back to defaultBatchWorkers if unset or invalid.
+func batchWorkersFromEnv() int {
+	if raw := os.Getenv("METALINK_BATCH_WORKERS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultBatchWorkers
+}
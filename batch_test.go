@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/tomoconnor/metalink/internal/cache"
+	"github.com/tomoconnor/metalink/internal/fetch"
+	"github.com/tomoconnor/metalink/internal/handlers"
+)
+
+func newTestBatchHandler(t *testing.T, f *fetch.Fetcher) echo.HandlerFunc {
+	t.Helper()
+	registry := handlers.NewRegistry()
+	metaCache, err := cache.New(1024, time.Minute, time.Minute)
+	if err != nil {
+		t.Fatalf("cache.New: %v", err)
+	}
+	return getBatchMetadataHandler(registry, f, metaCache)
+}
+
+func doBatchRequest(t *testing.T, handler echo.HandlerFunc, urls []string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(batchRequest{URLs: urls})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/metadata/batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	if err := handler(c); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	return rec
+}
+
+func TestBatchHandler_RejectsOverMaxSize(t *testing.T) {
+	handler := newTestBatchHandler(t, fetch.NewFetcher())
+
+	urls := make([]string, maxBatchSize+1)
+	for i := range urls {
+		urls[i] = "https://example.com/" + strings.Repeat("a", i%5)
+	}
+
+	rec := doBatchRequest(t, handler, urls)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestBatchHandler_PerURLSuccessAndErrorMix(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><head><title>Test Page</title></head></html>"))
+	}))
+	defer srv.Close()
+
+	f := fetch.NewFetcher(fetch.WithAllowedHosts("127.0.0.1"), fetch.WithRobotsCheck(false))
+	handler := newTestBatchHandler(t, f)
+
+	goodURL := srv.URL + "/page"
+	badURL := "://not-a-url"
+
+	rec := doBatchRequest(t, handler, []string{goodURL, badURL})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var results map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+
+	if !strings.Contains(string(results[goodURL]), "Test Page") {
+		t.Errorf("results[%q] = %s, want metadata containing title", goodURL, results[goodURL])
+	}
+	if !strings.Contains(string(results[badURL]), `"error"`) {
+		t.Errorf("results[%q] = %s, want an error object", badURL, results[badURL])
+	}
+}
+
+func TestBatchHandler_BoundsConcurrency(t *testing.T) {
+	const workers = 2
+
+	var current, maxObserved int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			old := atomic.LoadInt32(&maxObserved)
+			if n <= old || atomic.CompareAndSwapInt32(&maxObserved, old, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><head><title>t</title></head></html>"))
+	}))
+	defer srv.Close()
+
+	t.Setenv("METALINK_BATCH_WORKERS", "2")
+
+	f := fetch.NewFetcher(fetch.WithAllowedHosts("127.0.0.1"), fetch.WithRobotsCheck(false))
+	handler := newTestBatchHandler(t, f)
+
+	urls := make([]string, 6)
+	for i := range urls {
+		urls[i] = srv.URL + "/" + strings.Repeat("x", i+1)
+	}
+
+	rec := doBatchRequest(t, handler, urls)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	if got := atomic.LoadInt32(&maxObserved); got > workers {
+		t.Errorf("max concurrent fetches = %d, want <= %d", got, workers)
+	}
+}
+
+func TestBatchHandler_ClientCancelStopsQueuedFetches(t *testing.T) {
+	// A single worker means the second and third URLs queue on the
+	// semaphore behind the first; cancelling while they're queued should
+	// surface as the batch-cancelled response rather than waiting them
+	// out.
+	t.Setenv("METALINK_BATCH_WORKERS", "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><head><title>t</title></head></html>"))
+	}))
+	defer srv.Close()
+
+	f := fetch.NewFetcher(fetch.WithAllowedHosts("127.0.0.1"), fetch.WithRobotsCheck(false))
+	handler := newTestBatchHandler(t, f)
+
+	body, err := json.Marshal(batchRequest{URLs: []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c"}})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodPost, "/metadata/batch", bytes.NewReader(body)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	if err := handler(c); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want %d, body = %s", rec.Code, http.StatusBadGateway, rec.Body.String())
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/tomoconnor/metalink/internal/fetch"
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// landscapeTarget is the OG-recommended 1.91:1 image aspect ratio;
+// candidates closer to it score higher.
+const landscapeTarget = 1.91 / 1
+
+// maxFallbackImages caps how many <img> tags collectFallbackImages will
+// harvest from a single page, so a page with hundreds of images can't
+// balloon the response or, combined with probing, the number of
+// outbound requests a single /metadata call makes.
+const maxFallbackImages = 20
+
+// maxProbedImages caps how many images probeImageDimensions will fetch
+// per page, so ?probe=1 can't be used to turn one /metadata request
+// into an unbounded number of outbound requests.
+const maxProbedImages = 10
+
+// collectOGImages gathers og:image candidates along with whatever
+// og:image:width/height/type/alt properties follow each one in document
+// order, per the OpenGraph structured-property convention.
+func collectOGImages(doc *goquery.Document, parsedURL *url.URL) []metadata.Image {
+	var images []metadata.Image
+	doc.Find("meta").Each(func(i int, s *goquery.Selection) {
+		property, ok := s.Attr("property")
+		if !ok {
+			return
+		}
+		content, ok := s.Attr("content")
+		if !ok {
+			return
+		}
+
+		switch property {
+		case "og:image":
+			images = append(images, metadata.Image{URL: resolveURL(parsedURL, content)})
+		case "og:image:width":
+			if len(images) > 0 {
+				images[len(images)-1].Width = atoiOrZero(content)
+			}
+		case "og:image:height":
+			if len(images) > 0 {
+				images[len(images)-1].Height = atoiOrZero(content)
+			}
+		case "og:image:type":
+			if len(images) > 0 {
+				images[len(images)-1].Type = content
+			}
+		case "og:image:alt":
+			if len(images) > 0 {
+				images[len(images)-1].Alt = content
+			}
+		}
+	})
+	return images
+}
+
+// collectFallbackImages harvests <img> tags when a page has no og:image,
+// reading width/height/alt straight off the tag's attributes. It stops
+// after maxFallbackImages tags.
+func collectFallbackImages(doc *goquery.Document, parsedURL *url.URL) []metadata.Image {
+	var images []metadata.Image
+	doc.Find("img").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if len(images) >= maxFallbackImages {
+			return false
+		}
+		src, ok := s.Attr("src")
+		if !ok {
+			return true
+		}
+		img := metadata.Image{URL: resolveURL(parsedURL, src)}
+		img.Width = atoiOrZero(attrOrEmpty(s, "width"))
+		img.Height = atoiOrZero(attrOrEmpty(s, "height"))
+		img.Alt = attrOrEmpty(s, "alt")
+		images = append(images, img)
+		return true
+	})
+	return images
+}
+
+// attrOrEmpty returns s's attr, or "" if it isn't set.
+func attrOrEmpty(s *goquery.Selection, attr string) string {
+	v, _ := s.Attr(attr)
+	return v
+}
+
+// probeImageDimensions fills in Width/Height/Type for images that don't
+// already have dimensions, by partially fetching each and decoding its
+// image header. Probe failures are ignored; the image is left as-is.
+// Only the first maxProbedImages candidates are probed, so ?probe=1
+// can't turn a page with many images into an unbounded fan-out of
+// outbound requests.
+func probeImageDimensions(ctx context.Context, fetcher *fetch.Fetcher, images []metadata.Image) {
+	if len(images) > maxProbedImages {
+		images = images[:maxProbedImages]
+	}
+	for i := range images {
+		if images[i].Width != 0 && images[i].Height != 0 {
+			continue
+		}
+		width, height, mimeType, err := fetcher.ProbeImage(ctx, images[i].URL)
+		if err != nil {
+			continue
+		}
+		images[i].Width = width
+		images[i].Height = height
+		if images[i].Type == "" {
+			images[i].Type = mimeType
+		}
+	}
+}
+
+// selectPrimaryImage scores every candidate and returns the best one.
+// ogImageCount is how many of images' leading entries came from og:image
+// tags, which are strongly preferred over harvested <img> candidates.
+func selectPrimaryImage(images []metadata.Image, ogImageCount int) *metadata.Image {
+	if len(images) == 0 {
+		return nil
+	}
+	best := 0
+	bestScore := scoreImage(images[0], 0 < ogImageCount)
+	for i := 1; i < len(images); i++ {
+		score := scoreImage(images[i], i < ogImageCount)
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return &images[best]
+}
+
+// scoreImage ranks a candidate primary image: og:image tags are
+// preferred, as are HTTPS URLs, larger areas, and aspect ratios close to
+// OG's recommended 1.91:1. Images too small to be anything but a
+// tracking pixel or icon are penalized.
+func scoreImage(img metadata.Image, isOGImage bool) float64 {
+	var score float64
+	if isOGImage {
+		score += 100
+	}
+	if strings.HasPrefix(img.URL, "https://") {
+		score += 10
+	}
+
+	if img.Width > 0 && img.Height > 0 {
+		area := img.Width * img.Height
+		score += float64(area) / 10000
+
+		if img.Width > 200 && img.Height > 200 {
+			score += 20
+		} else {
+			score -= 50 // likely a tracking pixel or small icon
+		}
+
+		ratio := float64(img.Width) / float64(img.Height)
+		score -= math.Abs(ratio-landscapeTarget) * 5
+	}
+
+	return score
+}
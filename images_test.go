@@ -0,0 +1,122 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+func mustParseDoc(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("parse html: %v", err)
+	}
+	return doc
+}
+
+func TestCollectOGImages_AssociatesStructuredPropertiesInDocumentOrder(t *testing.T) {
+	html := `<html><head>
+		<meta property="og:image" content="/a.jpg">
+		<meta property="og:image:width" content="800">
+		<meta property="og:image:height" content="600">
+		<meta property="og:image:type" content="image/jpeg">
+		<meta property="og:image:alt" content="first image">
+		<meta property="og:image" content="/b.jpg">
+		<meta property="og:image:width" content="100">
+		<meta property="og:image:height" content="50">
+	</head></html>`
+	parsedURL, _ := url.Parse("https://example.com/page")
+	doc := mustParseDoc(t, html)
+
+	images := collectOGImages(doc, parsedURL)
+	if len(images) != 2 {
+		t.Fatalf("len(images) = %d, want 2", len(images))
+	}
+
+	first := images[0]
+	if first.URL != "https://example.com/a.jpg" || first.Width != 800 || first.Height != 600 ||
+		first.Type != "image/jpeg" || first.Alt != "first image" {
+		t.Errorf("images[0] = %+v, want resolved URL with all structured properties", first)
+	}
+
+	second := images[1]
+	if second.URL != "https://example.com/b.jpg" || second.Width != 100 || second.Height != 50 {
+		t.Errorf("images[1] = %+v, want second og:image's own width/height, not leaking from the first", second)
+	}
+	if second.Type != "" || second.Alt != "" {
+		t.Errorf("images[1] = %+v, want no type/alt carried over from images[0]", second)
+	}
+}
+
+func TestCollectOGImages_IgnoresStructuredPropertiesBeforeAnyImage(t *testing.T) {
+	html := `<html><head>
+		<meta property="og:image:width" content="800">
+		<meta property="og:image" content="/a.jpg">
+	</head></html>`
+	parsedURL, _ := url.Parse("https://example.com/page")
+	doc := mustParseDoc(t, html)
+
+	images := collectOGImages(doc, parsedURL)
+	if len(images) != 1 {
+		t.Fatalf("len(images) = %d, want 1", len(images))
+	}
+	if images[0].Width != 0 {
+		t.Errorf("images[0].Width = %d, want 0 (property appeared before any og:image)", images[0].Width)
+	}
+}
+
+func TestSelectPrimaryImage_PrefersOGImageOverFallback(t *testing.T) {
+	images := []metadata.Image{
+		{URL: "https://example.com/og.jpg", Width: 300, Height: 300},
+		{URL: "https://example.com/bigger-fallback.jpg", Width: 400, Height: 400},
+	}
+	got := selectPrimaryImage(images, 1)
+	if got != &images[0] {
+		t.Errorf("selectPrimaryImage() = %+v, want the og:image candidate despite its slightly smaller size", got)
+	}
+}
+
+func TestSelectPrimaryImage_PenalizesSmallImage(t *testing.T) {
+	images := []metadata.Image{
+		{URL: "https://example.com/icon.png", Width: 16, Height: 16},
+		{URL: "https://example.com/photo.jpg", Width: 1200, Height: 628},
+	}
+	got := selectPrimaryImage(images, 0)
+	if got != &images[1] {
+		t.Errorf("selectPrimaryImage() = %+v, want the larger non-icon image", got)
+	}
+}
+
+func TestSelectPrimaryImage_NoCandidatesReturnsNil(t *testing.T) {
+	if got := selectPrimaryImage(nil, 0); got != nil {
+		t.Errorf("selectPrimaryImage(nil) = %v, want nil", got)
+	}
+}
+
+func TestScoreImage_RewardsHTTPSAndOGOrigin(t *testing.T) {
+	https := scoreImage(metadata.Image{URL: "https://example.com/a.jpg"}, false)
+	http := scoreImage(metadata.Image{URL: "http://example.com/a.jpg"}, false)
+	if https <= http {
+		t.Errorf("scoreImage(https) = %v, want higher than scoreImage(http) = %v", https, http)
+	}
+
+	og := scoreImage(metadata.Image{URL: "https://example.com/a.jpg"}, true)
+	if og <= https {
+		t.Errorf("scoreImage(og=true) = %v, want higher than scoreImage(og=false) = %v", og, https)
+	}
+}
+
+func TestScoreImage_PrefersRatioCloserToOGTarget(t *testing.T) {
+	// Nearly the same area (753,600 vs 753,424 px), so the ratio term
+	// decides the comparison.
+	landscape := scoreImage(metadata.Image{URL: "https://example.com/a.jpg", Width: 1200, Height: 628}, false)
+	square := scoreImage(metadata.Image{URL: "https://example.com/a.jpg", Width: 868, Height: 868}, false)
+	if landscape <= square {
+		t.Errorf("scoreImage(1.91:1) = %v, want higher than scoreImage(1:1) = %v", landscape, square)
+	}
+}
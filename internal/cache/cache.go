@@ -0,0 +1,95 @@
+// Package cache provides an in-process TTL cache over URL metadata
+// lookups, collapsing concurrent lookups for the same URL via
+// singleflight so a burst of requests for one URL only fetches it once.
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// StatusCoder is implemented by errors that carry an upstream HTTP status
+// code. Only errors satisfying it are negatively cached — a plain error
+// (e.g. a network timeout) isn't assumed to be stable enough to cache.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+type entry struct {
+	value     any
+	err       error
+	expiresAt time.Time
+}
+
+// Cache is an in-process TTL cache over URL metadata lookups.
+type Cache struct {
+	lru         *lru.Cache[string, entry]
+	group       singleflight.Group
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	hits, misses atomic.Int64
+	// evictions counts only capacity-driven evictions (via the
+	// NewWithEvict callback), not TTL expirations.
+	evictions atomic.Int64
+}
+
+// New returns a Cache holding up to size entries. Successful results are
+// kept for ttl; results whose error implements StatusCoder with a 4xx/5xx
+// code are kept for negativeTTL to avoid hammering a failing upstream.
+func New(size int, ttl, negativeTTL time.Duration) (*Cache, error) {
+	c := &Cache{ttl: ttl, negativeTTL: negativeTTL}
+	l, err := lru.NewWithEvict[string, entry](size, func(string, entry) {
+		c.evictions.Add(1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.lru = l
+	return c, nil
+}
+
+// GetOrFetch returns the cached result for rawURL if present and
+// unexpired. Otherwise it calls fetch, deduplicating concurrent calls for
+// the same normalized URL, and caches the outcome according to its error.
+func (c *Cache) GetOrFetch(ctx context.Context, rawURL string, fetch func(context.Context) (any, error)) (any, error) {
+	key := NormalizeURL(rawURL)
+
+	if e, ok := c.lru.Get(key); ok {
+		if time.Now().Before(e.expiresAt) {
+			c.hits.Add(1)
+			return e.value, e.err
+		}
+		// Leave the expired entry in place rather than removing it: Remove
+		// would fire the NewWithEvict callback and inflate the eviction
+		// counter with something that isn't a capacity eviction. The
+		// fetch below overwrites it with a fresh entry via Add, unless it
+		// fails with an error that isn't cached.
+	}
+	c.misses.Add(1)
+
+	v, err, _ := c.group.Do(key, func() (any, error) {
+		value, ferr := fetch(ctx)
+
+		ttl := c.ttl
+		if ferr != nil {
+			sc, cacheable := ferr.(StatusCoder)
+			if !cacheable || sc.StatusCode() < 400 {
+				return value, ferr
+			}
+			ttl = c.negativeTTL
+		}
+		c.lru.Add(key, entry{value: value, err: ferr, expiresAt: time.Now().Add(ttl)})
+		return value, ferr
+	})
+	return v, err
+}
+
+// Stats returns the cache's cumulative hit/miss/eviction counters.
+func (c *Cache) Stats() (hits, misses, evictions int64) {
+	return c.hits.Load(), c.misses.Load(), c.evictions.Load()
+}
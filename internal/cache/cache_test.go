@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeStatusError struct{ code int }
+
+func (e *fakeStatusError) Error() string   { return "status error" }
+func (e *fakeStatusError) StatusCode() int { return e.code }
+
+func TestCache_DedupesConcurrentFetches(t *testing.T) {
+	c, err := New(10, time.Minute, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int32
+	fetch := func(ctx context.Context) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return "result", nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]any, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, _ := c.GetOrFetch(context.Background(), "https://example.com/page", fetch)
+			results[i] = v
+		}(i)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("upstream fetch called %d times, want 1", got)
+	}
+	for i, v := range results {
+		if v != "result" {
+			t.Errorf("results[%d] = %v, want %q", i, v, "result")
+		}
+	}
+}
+
+func TestCache_HitServesFromCacheWithoutRefetch(t *testing.T) {
+	c, err := New(10, time.Minute, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int32
+	fetch := func(ctx context.Context) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		return "v", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetOrFetch(context.Background(), "https://example.com/page", fetch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("upstream fetch called %d times, want 1", got)
+	}
+	hits, misses, _ := c.Stats()
+	if hits != 2 || misses != 1 {
+		t.Errorf("hits=%d misses=%d, want hits=2 misses=1", hits, misses)
+	}
+}
+
+func TestCache_NegativeResultIsCached(t *testing.T) {
+	c, err := New(10, time.Minute, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int32
+	fetch := func(ctx context.Context) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, &fakeStatusError{code: 404}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetOrFetch(context.Background(), "https://example.com/missing", fetch); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("upstream fetch called %d times, want 1 (negative result should be cached)", got)
+	}
+}
+
+func TestCache_PlainErrorIsNotCached(t *testing.T) {
+	c, err := New(10, time.Minute, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int32
+	fetch := func(ctx context.Context) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("network blip")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetOrFetch(context.Background(), "https://example.com/flaky", fetch); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("upstream fetch called %d times, want 2 (non-status errors shouldn't be cached)", got)
+	}
+}
+
+func TestCache_ExpiredEntryIsNotCountedAsEviction(t *testing.T) {
+	c, err := New(10, time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetch := func(ctx context.Context) (any, error) {
+		return "v", nil
+	}
+
+	if _, err := c.GetOrFetch(context.Background(), "https://example.com/page", fetch); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := c.GetOrFetch(context.Background(), "https://example.com/page", fetch); err != nil {
+		t.Fatal(err)
+	}
+
+	_, misses, evictions := c.Stats()
+	if misses != 2 {
+		t.Errorf("misses = %d, want 2 (both lookups missed: one cold, one expired)", misses)
+	}
+	if evictions != 0 {
+		t.Errorf("evictions = %d, want 0 (a TTL expiration is not a capacity eviction)", evictions)
+	}
+}
+
+func TestCache_CapacityEvictionIsCounted(t *testing.T) {
+	c, err := New(1, time.Minute, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetch := func(ctx context.Context) (any, error) {
+		return "v", nil
+	}
+
+	if _, err := c.GetOrFetch(context.Background(), "https://example.com/a", fetch); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetOrFetch(context.Background(), "https://example.com/b", fetch); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, evictions := c.Stats(); evictions != 1 {
+		t.Errorf("evictions = %d, want 1 (adding a second entry over capacity 1 should evict the first)", evictions)
+	}
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// trackingParamPrefixes matches query params that vary per-click but don't
+// change the resource being fetched, so they're stripped before caching.
+var trackingParamPrefixes = []string{"utm_"}
+
+// trackingParamNames are exact-match tracking params, in addition to the
+// prefix-matched ones above.
+var trackingParamNames = map[string]bool{
+	"fbclid": true,
+	"gclid":  true,
+}
+
+// NormalizeURL collapses URLs that point at the same resource onto the
+// same cache key: it lowercases the scheme and host, strips tracking
+// params, and sorts the remaining query string. The scheme is kept in
+// the key (not just host+path) since http and https URLs for the same
+// host can resolve to different content. It falls back to the raw
+// input if the URL can't be parsed, so it's always safe to use as a
+// cache key.
+func NormalizeURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	q := u.Query()
+	for key := range q {
+		lower := strings.ToLower(key)
+		if trackingParamNames[lower] || hasAnyPrefix(lower, trackingParamPrefixes) {
+			q.Del(key)
+		}
+	}
+
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sorted := url.Values{}
+	for _, k := range keys {
+		vals := append([]string(nil), q[k]...)
+		sort.Strings(vals)
+		sorted[k] = vals
+	}
+
+	key := strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + u.Path
+	if encoded := sorted.Encode(); encoded != "" {
+		key += "?" + encoded
+	}
+	return key
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package cache
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	cases := []struct {
+		a, b string
+	}{
+		{
+			"https://Example.com/page?utm_source=twitter&id=1",
+			"https://example.com/page?id=1",
+		},
+		{
+			"https://example.com/page?b=2&a=1",
+			"https://example.com/page?a=1&b=2",
+		},
+		{
+			"https://example.com/page?fbclid=abc123&id=1",
+			"https://example.com/page?id=1",
+		},
+	}
+	for _, tc := range cases {
+		if got, want := NormalizeURL(tc.a), NormalizeURL(tc.b); got != want {
+			t.Errorf("NormalizeURL(%q) = %q, NormalizeURL(%q) = %q, want equal", tc.a, got, tc.b, want)
+		}
+	}
+}
+
+func TestNormalizeURL_DistinguishesScheme(t *testing.T) {
+	http := NormalizeURL("http://example.com/page")
+	https := NormalizeURL("https://example.com/page")
+	if http == https {
+		t.Errorf("NormalizeURL(http) = NormalizeURL(https) = %q, want distinct keys", http)
+	}
+}
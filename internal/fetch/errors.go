@@ -0,0 +1,19 @@
+package fetch
+
+import "fmt"
+
+// statusError is returned when an upstream request responds with a
+// non-2xx status. It carries the status code so callers like the cache
+// layer can decide whether a failure is worth negatively caching.
+type statusError struct {
+	Code int
+	msg  string
+}
+
+func (e *statusError) Error() string   { return e.msg }
+func (e *statusError) StatusCode() int { return e.Code }
+
+// statusErrorf builds a statusError with a formatted message.
+func statusErrorf(code int, format string, args ...any) error {
+	return &statusError{Code: code, msg: fmt.Sprintf(format, args...)}
+}
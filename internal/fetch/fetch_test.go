@@ -0,0 +1,132 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newTestFetcher(t *testing.T, opts ...Option) *Fetcher {
+	t.Helper()
+	allOpts := append([]Option{WithAllowedHosts("127.0.0.1"), WithRobotsCheck(false)}, opts...)
+	return NewFetcher(allOpts...)
+}
+
+func TestFetcher_Fetch_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><title>hi</title></html>"))
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher(t)
+	result, err := f.Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", result.StatusCode)
+	}
+	if !strings.HasPrefix(result.ContentType, "text/html") {
+		t.Errorf("ContentType = %q, want text/html prefix", result.ContentType)
+	}
+	if string(result.Body) != "<html><title>hi</title></html>" {
+		t.Errorf("Body = %q", result.Body)
+	}
+}
+
+func TestFetcher_Fetch_RejectsPrivateHost(t *testing.T) {
+	f := NewFetcher(WithRobotsCheck(false))
+	_, err := f.Fetch(context.Background(), "http://127.0.0.1:1/")
+	if err == nil {
+		t.Fatal("expected Fetch to reject a loopback target, got nil error")
+	}
+}
+
+func TestFetcher_Fetch_MaxBodyBytesTruncates(t *testing.T) {
+	const fullBody = "0123456789"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fullBody))
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher(t, WithMaxBodyBytes(4))
+	result, err := f.Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(result.Body) != 4 {
+		t.Errorf("len(Body) = %d, want 4", len(result.Body))
+	}
+}
+
+func TestFetcher_Fetch_MaxRedirectsExceeded(t *testing.T) {
+	var srv *httptest.Server
+	hops := 0
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops++
+		http.Redirect(w, r, fmt.Sprintf("%s/hop-%d", srv.URL, hops), http.StatusFound)
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher(t, WithMaxRedirects(2))
+	_, err := f.Fetch(context.Background(), srv.URL)
+	if err == nil {
+		t.Fatal("expected too-many-redirects error, got nil")
+	}
+}
+
+func TestFetcher_Fetch_RedirectHostIsRevalidated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher(t)
+	_, err := f.Fetch(context.Background(), srv.URL)
+	if err == nil {
+		t.Fatal("expected redirect to a link-local address to be rejected")
+	}
+}
+
+func TestFetcher_Fetch_InvalidURL(t *testing.T) {
+	f := newTestFetcher(t)
+	if _, err := f.Fetch(context.Background(), "://bad"); err == nil {
+		t.Fatal("expected invalid url error, got nil")
+	}
+}
+
+func TestFetcher_Fetch_RobotsDisallowed(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	f := NewFetcher(WithAllowedHosts(hostOf(t, srv.URL)))
+	_, err := f.Fetch(context.Background(), srv.URL+"/private/page")
+	if err == nil {
+		t.Fatal("expected robots.txt to disallow /private, got nil error")
+	}
+
+	if _, err := f.Fetch(context.Background(), srv.URL+"/public"); err != nil {
+		t.Errorf("expected /public to be allowed, got %v", err)
+	}
+}
+
+func hostOf(t *testing.T, rawURL string) string {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,66 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/url"
+
+	_ "golang.org/x/image/webp"
+)
+
+// probeRangeBytes is how much of an image ProbeImage reads to decode its
+// header - comfortably more than PNG/JPEG/GIF/WEBP need for DecodeConfig.
+const probeRangeBytes = 4096
+
+// ProbeImage retrieves just enough of rawURL (a HEAD, then a ranged GET)
+// to read its image header, returning the real pixel dimensions and a
+// "image/<format>" MIME type. It's subject to the same SSRF host policy
+// as Fetch, but not the robots.txt check - probing an already-discovered
+// image isn't crawling.
+func (f *Fetcher) ProbeImage(ctx context.Context, rawURL string) (width, height int, mimeType string, err error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("fetch: invalid url: %w", err)
+	}
+	if err := f.checkHostAllowed(parsedURL.Hostname()); err != nil {
+		return 0, 0, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	if headReq, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil); err == nil {
+		headReq.Header.Set("User-Agent", userAgent)
+		if resp, err := f.client.Do(headReq); err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", probeRangeBytes-1))
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return 0, 0, "", statusErrorf(resp.StatusCode, "fetch: probe request returned status %d", resp.StatusCode)
+	}
+
+	cfg, format, err := image.DecodeConfig(io.LimitReader(resp.Body, probeRangeBytes))
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("fetch: decoding image header: %w", err)
+	}
+	return cfg.Width, cfg.Height, "image/" + format, nil
+}
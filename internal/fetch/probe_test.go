@@ -0,0 +1,45 @@
+package fetch
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// onePxPNG is a 1x1 opaque black grayscale PNG, small enough to embed directly rather
+// than as a testdata fixture.
+const onePxPNG = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNk+A8AAQUBAScY42YAAAAASUVORK5CYII="
+
+func TestFetcher_ProbeImage(t *testing.T) {
+	png, err := base64.StdEncoding.DecodeString(onePxPNG)
+	if err != nil {
+		t.Fatalf("decoding fixture: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(png)
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher(t)
+	width, height, mimeType, err := f.ProbeImage(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("ProbeImage() error = %v", err)
+	}
+	if width != 1 || height != 1 {
+		t.Errorf("dimensions = %dx%d, want 1x1", width, height)
+	}
+	if mimeType != "image/png" {
+		t.Errorf("mimeType = %q, want image/png", mimeType)
+	}
+}
+
+func TestFetcher_ProbeImage_RejectsDisallowedHost(t *testing.T) {
+	f := NewFetcher(WithRobotsCheck(false))
+	if _, _, _, err := f.ProbeImage(context.Background(), "http://127.0.0.1:1/x.png"); err == nil {
+		t.Error("expected loopback target to be rejected")
+	}
+}
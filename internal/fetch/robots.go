@@ -0,0 +1,118 @@
+package fetch
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// robotsTTL is how long a host's parsed robots.txt rules are cached
+// before being re-fetched.
+const robotsTTL = 1 * time.Hour
+
+// robotsRules is the subset of robots.txt this checker understands: the
+// Disallow paths listed under a "User-agent: *" group.
+type robotsRules struct {
+	disallow  []string
+	fetchedAt time.Time
+}
+
+// robotsChecker caches parsed robots.txt rules per host so repeated
+// fetches against the same site don't re-request it every time.
+type robotsChecker struct {
+	mu    sync.Mutex
+	cache map[string]robotsRules
+}
+
+func newRobotsChecker() *robotsChecker {
+	return &robotsChecker{cache: make(map[string]robotsRules)}
+}
+
+// Allowed reports whether target's path is permitted by its host's
+// robots.txt for the "*" user agent. A robots.txt that's missing or
+// unreadable is treated as allow-all, matching common crawler behavior.
+func (rc *robotsChecker) Allowed(ctx context.Context, client *http.Client, target *url.URL) (bool, error) {
+	rules, err := rc.rulesFor(ctx, client, target)
+	if err != nil {
+		return true, nil
+	}
+
+	for _, disallowed := range rules.disallow {
+		if disallowed != "" && strings.HasPrefix(target.Path, disallowed) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (rc *robotsChecker) rulesFor(ctx context.Context, client *http.Client, target *url.URL) (robotsRules, error) {
+	host := target.Hostname()
+
+	rc.mu.Lock()
+	if rules, ok := rc.cache[host]; ok && time.Since(rules.fetchedAt) < robotsTTL {
+		rc.mu.Unlock()
+		return rules, nil
+	}
+	rc.mu.Unlock()
+
+	robotsURL := url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/robots.txt"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return robotsRules{}, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return robotsRules{}, err
+	}
+	defer resp.Body.Close()
+
+	rules := robotsRules{fetchedAt: time.Now()}
+	if resp.StatusCode == http.StatusOK {
+		rules.disallow = parseRobotsDisallow(resp.Body)
+	}
+
+	rc.mu.Lock()
+	rc.cache[host] = rules
+	rc.mu.Unlock()
+
+	return rules, nil
+}
+
+// parseRobotsDisallow extracts Disallow paths from the "User-agent: *"
+// group(s) of a robots.txt body. It's a minimal parser covering the
+// common case, not the full robots.txt spec.
+func parseRobotsDisallow(body io.Reader) []string {
+	var disallow []string
+	inWildcardGroup := false
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "user-agent":
+			inWildcardGroup = value == "*"
+		case "disallow":
+			if inWildcardGroup {
+				disallow = append(disallow, value)
+			}
+		}
+	}
+	return disallow
+}
@@ -0,0 +1,44 @@
+package fetch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRobotsDisallow(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "wildcard group",
+			body: "User-agent: *\nDisallow: /private\nDisallow: /admin\n",
+			want: []string{"/private", "/admin"},
+		},
+		{
+			name: "ignores other agents",
+			body: "User-agent: Googlebot\nDisallow: /no-google\n\nUser-agent: *\nDisallow: /all\n",
+			want: []string{"/all"},
+		},
+		{
+			name: "no wildcard group",
+			body: "User-agent: Googlebot\nDisallow: /no-google\n",
+			want: nil,
+		},
+		{
+			name: "comments and blank lines ignored",
+			body: "# comment\nUser-agent: *\n\nDisallow: /x\n",
+			want: []string{"/x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseRobotsDisallow(strings.NewReader(tt.body))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRobotsDisallow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package fetch
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIsDisallowedIP(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want bool
+	}{
+		{"loopback v4", "127.0.0.1", true},
+		{"loopback v6", "::1", true},
+		{"link-local", "169.254.169.254", true},
+		{"private 10/8", "10.0.0.5", true},
+		{"private 192.168/16", "192.168.1.1", true},
+		{"multicast", "224.0.0.1", true},
+		{"unspecified", "0.0.0.0", true},
+		{"public", "8.8.8.8", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := net.ParseIP(tt.ip)
+			if ip == nil {
+				t.Fatalf("could not parse test IP %q", tt.ip)
+			}
+			if got := isDisallowedIP(ip); got != tt.want {
+				t.Errorf("isDisallowedIP(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetcher_CheckHostAllowed_Allowlist(t *testing.T) {
+	f := NewFetcher(WithAllowedHosts("localhost"))
+	if err := f.checkHostAllowed("localhost"); err != nil {
+		t.Errorf("expected allowlisted host to pass, got %v", err)
+	}
+}
+
+func TestFetcher_CheckHostAllowed_RejectsLoopback(t *testing.T) {
+	f := NewFetcher()
+	if err := f.checkHostAllowed("127.0.0.1"); err == nil {
+		t.Error("expected loopback address to be rejected")
+	}
+}
+
+func TestFetcher_ResolveAllowedIP_DialsTheValidatedAddress(t *testing.T) {
+	f := NewFetcher(WithAllowedHosts("127.0.0.1"))
+	ip, err := f.resolveAllowedIP("127.0.0.1")
+	if err != nil {
+		t.Fatalf("resolveAllowedIP() error = %v", err)
+	}
+	if !ip.Equal(net.ParseIP("127.0.0.1")) {
+		t.Errorf("resolveAllowedIP() = %v, want 127.0.0.1", ip)
+	}
+}
+
+func TestFetcher_ResolveAllowedIP_RejectsDisallowedLiteral(t *testing.T) {
+	f := NewFetcher()
+	if _, err := f.resolveAllowedIP("127.0.0.1"); err == nil {
+		t.Error("expected loopback literal to be rejected")
+	}
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import "fmt"
+
+// StatusError is returned by handlers when an upstream API responds with a
+// non-2xx status. It carries the status code so callers like the cache
+// layer can decide whether a failure is worth negatively caching.
+type StatusError struct {
+	Code int
+	msg  string
+}
+
+func (e *StatusError) Error() string   { return e.msg }
+func (e *StatusError) StatusCode() int { return e.Code }
+
+// statusErrorf builds a StatusError with a formatted message.
+func statusErrorf(code int, format string, args ...any) error {
+	return &StatusError{Code: code, msg: fmt.Sprintf(format, args...)}
+}
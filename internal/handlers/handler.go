@@ -0,0 +1,46 @@
+// Package handlers provides per-host LinkHandler implementations and a
+// registry for dispatching a URL to the right one.
+package handlers
+
+import (
+	"context"
+	"net/url"
+)
+
+// LinkHandler knows how to produce metadata for a specific class of URL,
+// such as a single media-hosting site.
+type LinkHandler interface {
+	// Matches reports whether this handler should be used for u.
+	Matches(u *url.URL) bool
+	// Fetch resolves metadata for u. Only called after Matches returns
+	// true. The result is one of *metadata.Metadata, *metadata.PlaylistMetadata
+	// or *metadata.ChannelMetadata, each identifiable by its Type field.
+	Fetch(ctx context.Context, u *url.URL) (any, error)
+}
+
+// Registry dispatches a URL to the first registered handler that matches it.
+type Registry struct {
+	handlers []LinkHandler
+}
+
+// NewRegistry returns an empty handler registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds h to the registry. Handlers are tried in registration order,
+// so more specific handlers should be registered before general ones.
+func (r *Registry) Register(h LinkHandler) {
+	r.handlers = append(r.handlers, h)
+}
+
+// Lookup returns the first registered handler whose Matches reports true
+// for u, and false if none do.
+func (r *Registry) Lookup(u *url.URL) (LinkHandler, bool) {
+	for _, h := range r.handlers {
+		if h.Matches(u) {
+			return h, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// stubHandler is a minimal LinkHandler for exercising Registry dispatch.
+type stubHandler struct {
+	host string
+	meta *metadata.Metadata
+}
+
+func (s *stubHandler) Matches(u *url.URL) bool { return u.Hostname() == s.host }
+func (s *stubHandler) Fetch(ctx context.Context, u *url.URL) (any, error) {
+	return s.meta, nil
+}
+
+func TestRegistry_LookupReturnsFirstMatch(t *testing.T) {
+	r := NewRegistry()
+	a := &stubHandler{host: "example.com", meta: &metadata.Metadata{Title: "a"}}
+	b := &stubHandler{host: "example.com", meta: &metadata.Metadata{Title: "b"}}
+	r.Register(a)
+	r.Register(b)
+
+	u, _ := url.Parse("https://example.com/page")
+	got, ok := r.Lookup(u)
+	if !ok {
+		t.Fatal("Lookup: ok = false, want true")
+	}
+	if got != a {
+		t.Error("Lookup did not return the first registered match")
+	}
+}
+
+func TestRegistry_LookupNoMatch(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&stubHandler{host: "example.com"})
+
+	u, _ := url.Parse("https://other.com/page")
+	if _, ok := r.Lookup(u); ok {
+		t.Error("Lookup: ok = true, want false")
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// imgurAPIResponse models the envelope returned by api.imgur.com/3/*.
+type imgurAPIResponse struct {
+	Data struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Link        string `json:"link"`
+		Cover       string `json:"cover"` // album cover image ID, if any
+	} `json:"data"`
+	Success bool `json:"success"`
+}
+
+// ImgurHandler resolves metadata for imgur.com images and albums via the
+// Imgur API. It requires a client ID (IMGUR_CLIENT_ID); without one it
+// should not be registered.
+type ImgurHandler struct {
+	ClientID   string
+	HTTPClient *http.Client
+
+	apiBaseURL string // overridable in tests
+}
+
+// NewImgurHandler returns an ImgurHandler authenticated with clientID.
+func NewImgurHandler(clientID string) *ImgurHandler {
+	return &ImgurHandler{
+		ClientID:   clientID,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+		apiBaseURL: "https://api.imgur.com/3",
+	}
+}
+
+// Matches returns true if the host is imgur.com or i.imgur.com.
+func (h *ImgurHandler) Matches(u *url.URL) bool {
+	return strings.Contains(u.Hostname(), "imgur.com")
+}
+
+// Fetch resolves metadata for an imgur image or album URL.
+func (h *ImgurHandler) Fetch(ctx context.Context, u *url.URL) (any, error) {
+	kind, id := imgurResourceID(u)
+	if id == "" {
+		return nil, fmt.Errorf("imgur: could not extract resource id from %s", u)
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s", h.apiBaseURL, kind, id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Client-ID "+h.ClientID)
+
+	resp, err := h.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusErrorf(resp.StatusCode, "imgur: API error: status %d", resp.StatusCode)
+	}
+
+	var data imgurAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	if !data.Success {
+		return nil, fmt.Errorf("imgur: API reported failure for %s", id)
+	}
+
+	image := data.Data.Link
+	if image == "" && data.Data.Cover != "" {
+		image = fmt.Sprintf("https://i.imgur.com/%s.jpg", data.Data.Cover)
+	}
+
+	meta := &metadata.Metadata{
+		Type:        "image",
+		URL:         u.String(),
+		PageName:    "Imgur",
+		SiteName:    "Imgur",
+		Title:       data.Data.Title,
+		Description: data.Data.Description,
+	}
+	if image != "" {
+		meta.Images = []metadata.Image{{URL: image}}
+	}
+	return meta, nil
+}
+
+// imgurResourceID classifies an imgur URL as an "album" or "image" and
+// extracts its ID, e.g. "imgur.com/a/AbCdE12" -> ("album", "AbCdE12").
+func imgurResourceID(u *url.URL) (kind, id string) {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) == 0 || parts[0] == "" {
+		return "", ""
+	}
+	if parts[0] == "a" || parts[0] == "gallery" {
+		if len(parts) < 2 {
+			return "", ""
+		}
+		return "album", parts[1]
+	}
+	// Bare image IDs may have an extension, e.g. "AbCdE12.jpg".
+	id = parts[0]
+	if dot := strings.LastIndex(id, "."); dot != -1 {
+		id = id[:dot]
+	}
+	return "image", id
+}
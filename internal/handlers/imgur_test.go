@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+func TestImgurHandler_Matches(t *testing.T) {
+	h := NewImgurHandler("client-id")
+	match, _ := url.Parse("https://imgur.com/a/AbCdE12")
+	noMatch, _ := url.Parse("https://vimeo.com/12345")
+	if !h.Matches(match) {
+		t.Error("Matches(imgur url) = false, want true")
+	}
+	if h.Matches(noMatch) {
+		t.Error("Matches(non-imgur url) = true, want false")
+	}
+}
+
+func TestImgurHandler_Fetch(t *testing.T) {
+	cases := []struct {
+		name      string
+		rawURL    string
+		fixture   string
+		wantTitle string
+	}{
+		{"image", "https://imgur.com/AbCdE12", "testdata/imgur_image.json", "Cool cat"},
+		{"album", "https://imgur.com/a/XyZ9876", "testdata/imgur_album.json", "Cat album"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotAuth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAuth = r.Header.Get("Authorization")
+				serveFixture(t, w, tc.fixture)
+			}))
+			defer srv.Close()
+
+			h := NewImgurHandler("test-client-id")
+			h.apiBaseURL = srv.URL
+
+			u, _ := url.Parse(tc.rawURL)
+			got, err := h.Fetch(context.Background(), u)
+			if err != nil {
+				t.Fatalf("Fetch: %v", err)
+			}
+			meta, ok := got.(*metadata.Metadata)
+			if !ok {
+				t.Fatalf("Fetch returned %T, want *metadata.Metadata", got)
+			}
+			if meta.Title != tc.wantTitle {
+				t.Errorf("Title = %q, want %q", meta.Title, tc.wantTitle)
+			}
+			if gotAuth != "Client-ID test-client-id" {
+				t.Errorf("Authorization header = %q", gotAuth)
+			}
+		})
+	}
+}
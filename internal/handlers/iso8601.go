@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// iso8601DurationPattern matches the subset of ISO-8601 durations the
+// YouTube Data API emits, e.g. "PT4M13S", "PT1H2M3S", "P1DT2H".
+var iso8601DurationPattern = regexp.MustCompile(
+	`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`,
+)
+
+// parseISO8601Duration converts an ISO-8601 duration string into a
+// time.Duration.
+func parseISO8601Duration(s string) (time.Duration, error) {
+	m := iso8601DurationPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("iso8601: invalid duration %q", s)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("iso8601: invalid duration %q: %w", s, err)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"PT4M13S", 4*time.Minute + 13*time.Second},
+		{"PT1H2M3S", time.Hour + 2*time.Minute + 3*time.Second},
+		{"P1DT2H", 24*time.Hour + 2*time.Hour},
+		{"PT30S", 30 * time.Second},
+	}
+	for _, tc := range cases {
+		got, err := parseISO8601Duration(tc.in)
+		if err != nil {
+			t.Fatalf("parseISO8601Duration(%q): %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("parseISO8601Duration(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseISO8601Duration_Invalid(t *testing.T) {
+	if _, err := parseISO8601Duration("not-a-duration"); err == nil {
+		t.Error("expected an error for an invalid duration string")
+	}
+}
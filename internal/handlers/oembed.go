@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// genericOEmbedResponse models the fields common to the oEmbed providers
+// metalink consumes (Twitter/X, Vimeo, SoundCloud).
+type genericOEmbedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// fetchGenericOEmbed calls an oEmbed endpoint with the page URL and maps the
+// response onto Metadata. It's shared by the simple oEmbed-only handlers.
+// mediaType becomes the result's Type discriminator (e.g. "tweet", "video").
+func fetchGenericOEmbed(ctx context.Context, client *http.Client, endpoint, pageURL, mediaType string) (*metadata.Metadata, error) {
+	oeURL := fmt.Sprintf("%s?url=%s&format=json", endpoint, url.QueryEscape(pageURL))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, oeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusErrorf(resp.StatusCode, "oembed error: status %d", resp.StatusCode)
+	}
+
+	var oe genericOEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&oe); err != nil {
+		return nil, err
+	}
+
+	pageName := oe.ProviderName
+	var images []metadata.Image
+	if oe.ThumbnailURL != "" {
+		images = []metadata.Image{{URL: oe.ThumbnailURL}}
+	}
+
+	return &metadata.Metadata{
+		Type:        mediaType,
+		URL:         pageURL,
+		PageName:    pageName,
+		SiteName:    pageName,
+		Title:       oe.Title,
+		Description: oe.AuthorName,
+		Author:      oe.AuthorName,
+		Images:      images,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// oEmbedHandlerCase exercises the simple handlers that only wrap
+// fetchGenericOEmbed: Twitter, Vimeo, SoundCloud.
+type oEmbedHandlerCase struct {
+	name       string
+	newHandler func() LinkHandler
+	setBaseURL func(h LinkHandler, url string)
+	matchURL   string
+	noMatchURL string
+	fixture    string
+	wantTitle  string
+}
+
+func oEmbedCases() []oEmbedHandlerCase {
+	return []oEmbedHandlerCase{
+		{
+			name:       "twitter",
+			newHandler: func() LinkHandler { return NewTwitterHandler() },
+			setBaseURL: func(h LinkHandler, u string) { h.(*TwitterHandler).oEmbedURL = u },
+			matchURL:   "https://twitter.com/jack/status/20",
+			noMatchURL: "https://vimeo.com/20",
+			fixture:    "testdata/twitter_oembed.json",
+			wantTitle:  "A tweet",
+		},
+		{
+			name:       "vimeo",
+			newHandler: func() LinkHandler { return NewVimeoHandler() },
+			setBaseURL: func(h LinkHandler, u string) { h.(*VimeoHandler).oEmbedURL = u },
+			matchURL:   "https://vimeo.com/12345",
+			noMatchURL: "https://soundcloud.com/example/track",
+			fixture:    "testdata/vimeo_oembed.json",
+			wantTitle:  "A Vimeo video",
+		},
+		{
+			name:       "soundcloud",
+			newHandler: func() LinkHandler { return NewSoundCloudHandler() },
+			setBaseURL: func(h LinkHandler, u string) { h.(*SoundCloudHandler).oEmbedURL = u },
+			matchURL:   "https://soundcloud.com/example/track",
+			noMatchURL: "https://twitter.com/jack/status/20",
+			fixture:    "testdata/soundcloud_oembed.json",
+			wantTitle:  "A SoundCloud track",
+		},
+	}
+}
+
+func TestOEmbedHandlers_Matches(t *testing.T) {
+	for _, tc := range oEmbedCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			h := tc.newHandler()
+			match, err := url.Parse(tc.matchURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			noMatch, err := url.Parse(tc.noMatchURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !h.Matches(match) {
+				t.Errorf("Matches(%q) = false, want true", tc.matchURL)
+			}
+			if h.Matches(noMatch) {
+				t.Errorf("Matches(%q) = true, want false", tc.noMatchURL)
+			}
+		})
+	}
+}
+
+func TestOEmbedHandlers_Fetch(t *testing.T) {
+	for _, tc := range oEmbedCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				serveFixture(t, w, tc.fixture)
+			}))
+			defer srv.Close()
+
+			h := tc.newHandler()
+			tc.setBaseURL(h, srv.URL)
+
+			u, _ := url.Parse(tc.matchURL)
+			got, err := h.Fetch(context.Background(), u)
+			if err != nil {
+				t.Fatalf("Fetch: %v", err)
+			}
+			meta, ok := got.(*metadata.Metadata)
+			if !ok {
+				t.Fatalf("Fetch returned %T, want *metadata.Metadata", got)
+			}
+			if meta.Title != tc.wantTitle {
+				t.Errorf("Title = %q, want %q", meta.Title, tc.wantTitle)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SoundCloudHandler resolves metadata for soundcloud.com URLs via
+// SoundCloud's oEmbed endpoint.
+type SoundCloudHandler struct {
+	HTTPClient *http.Client
+
+	oEmbedURL string // overridable in tests
+}
+
+// NewSoundCloudHandler returns a SoundCloudHandler.
+func NewSoundCloudHandler() *SoundCloudHandler {
+	return &SoundCloudHandler{
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+		oEmbedURL:  "https://soundcloud.com/oembed",
+	}
+}
+
+// Matches returns true if the host is soundcloud.com.
+func (h *SoundCloudHandler) Matches(u *url.URL) bool {
+	return strings.Contains(u.Hostname(), "soundcloud.com")
+}
+
+// Fetch resolves metadata for a SoundCloud track or playlist URL.
+func (h *SoundCloudHandler) Fetch(ctx context.Context, u *url.URL) (any, error) {
+	return fetchGenericOEmbed(ctx, h.HTTPClient, h.oEmbedURL, u.String(), "audio")
+}
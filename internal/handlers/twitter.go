@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TwitterHandler resolves metadata for twitter.com / x.com posts via
+// Twitter's public oEmbed endpoint.
+type TwitterHandler struct {
+	HTTPClient *http.Client
+
+	oEmbedURL string // overridable in tests
+}
+
+// NewTwitterHandler returns a TwitterHandler.
+func NewTwitterHandler() *TwitterHandler {
+	return &TwitterHandler{
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+		oEmbedURL:  "https://publish.twitter.com/oembed",
+	}
+}
+
+// Matches returns true if the host is twitter.com or x.com (or a
+// subdomain of either).
+func (h *TwitterHandler) Matches(u *url.URL) bool {
+	host := u.Hostname()
+	return isHostOrSubdomain(host, "twitter.com") || isHostOrSubdomain(host, "x.com")
+}
+
+// isHostOrSubdomain reports whether host is exactly domain or a
+// subdomain of it, unlike a bare strings.Contains check which would
+// also match unrelated hosts that merely end in the same letters (e.g.
+// "x.com" matching "netflix.com").
+func isHostOrSubdomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// Fetch resolves metadata for a tweet URL.
+func (h *TwitterHandler) Fetch(ctx context.Context, u *url.URL) (any, error) {
+	return fetchGenericOEmbed(ctx, h.HTTPClient, h.oEmbedURL, u.String(), "tweet")
+}
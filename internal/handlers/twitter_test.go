@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestTwitterHandler_Matches(t *testing.T) {
+	h := NewTwitterHandler()
+	cases := []struct {
+		rawURL string
+		want   bool
+	}{
+		{"https://twitter.com/someone/status/123", true},
+		{"https://x.com/someone/status/123", true},
+		{"https://mobile.twitter.com/someone/status/123", true},
+		{"https://www.x.com/someone/status/123", true},
+		{"https://dropbox.com/s/abc", false},
+		{"https://netflix.com/watch/123", false},
+		{"https://box.com/s/abc", false},
+		{"https://linux.com/", false},
+	}
+	for _, tc := range cases {
+		u, err := url.Parse(tc.rawURL)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.rawURL, err)
+		}
+		if got := h.Matches(u); got != tc.want {
+			t.Errorf("Matches(%q) = %v, want %v", tc.rawURL, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VimeoHandler resolves metadata for vimeo.com URLs via Vimeo's oEmbed
+// endpoint.
+type VimeoHandler struct {
+	HTTPClient *http.Client
+
+	oEmbedURL string // overridable in tests
+}
+
+// NewVimeoHandler returns a VimeoHandler.
+func NewVimeoHandler() *VimeoHandler {
+	return &VimeoHandler{
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+		oEmbedURL:  "https://vimeo.com/api/oembed.json",
+	}
+}
+
+// Matches returns true if the host is vimeo.com.
+func (h *VimeoHandler) Matches(u *url.URL) bool {
+	return strings.Contains(u.Hostname(), "vimeo.com")
+}
+
+// Fetch resolves metadata for a Vimeo video URL.
+func (h *VimeoHandler) Fetch(ctx context.Context, u *url.URL) (any, error) {
+	return fetchGenericOEmbed(ctx, h.HTTPClient, h.oEmbedURL, u.String(), "video")
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// oEmbedResponse models JSON from YouTube's oEmbed endpoint.
+type oEmbedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	Provider     string `json:"provider_name"`
+}
+
+// youtubeAPIResponse models JSON from YouTube Data API v3's videos endpoint.
+type youtubeAPIResponse struct {
+	Items []struct {
+		Snippet struct {
+			Title        string    `json:"title"`
+			Description  string    `json:"description"`
+			ChannelTitle string    `json:"channelTitle"`
+			PublishedAt  time.Time `json:"publishedAt"`
+			Thumbnails   map[string]struct {
+				URL string `json:"url"`
+			} `json:"thumbnails"`
+		} `json:"snippet"`
+		ContentDetails struct {
+			Duration string `json:"duration"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+// YouTubeHandler resolves metadata for youtube.com / youtu.be URLs, trying
+// the public oEmbed endpoint first and falling back to the Data API v3
+// when an API key is configured.
+type YouTubeHandler struct {
+	APIKey     string
+	HTTPClient *http.Client
+
+	oEmbedURL        string // overridable in tests
+	dataAPIURL       string // overridable in tests
+	playlistsURL     string // overridable in tests
+	playlistItemsURL string // overridable in tests
+	channelsURL      string // overridable in tests
+}
+
+// NewYouTubeHandler returns a YouTubeHandler. apiKey may be empty, in which
+// case Fetch only ever tries oEmbed, and playlist/channel lookups fail.
+func NewYouTubeHandler(apiKey string) *YouTubeHandler {
+	return &YouTubeHandler{
+		APIKey:           apiKey,
+		HTTPClient:       &http.Client{Timeout: 5 * time.Second},
+		oEmbedURL:        "https://www.youtube.com/oembed",
+		dataAPIURL:       "https://www.googleapis.com/youtube/v3/videos",
+		playlistsURL:     "https://www.googleapis.com/youtube/v3/playlists",
+		playlistItemsURL: "https://www.googleapis.com/youtube/v3/playlistItems",
+		channelsURL:      "https://www.googleapis.com/youtube/v3/channels",
+	}
+}
+
+// Matches returns true if the host is YouTube or youtu.be.
+func (h *YouTubeHandler) Matches(u *url.URL) bool {
+	host := u.Hostname()
+	return strings.Contains(host, "youtube.com") || strings.Contains(host, "youtu.be")
+}
+
+// Fetch resolves metadata for a YouTube video, playlist, or channel URL,
+// returning a *metadata.Metadata, *metadata.PlaylistMetadata or
+// *metadata.ChannelMetadata respectively.
+func (h *YouTubeHandler) Fetch(ctx context.Context, u *url.URL) (any, error) {
+	switch classifyYouTubeURL(u) {
+	case youtubeKindPlaylist:
+		return h.fetchPlaylistSummary(ctx, u.Query().Get("list"))
+	case youtubeKindChannel:
+		return h.fetchChannel(ctx, u)
+	default:
+		return h.fetchVideo(ctx, u)
+	}
+}
+
+// fetchVideo resolves metadata for a single YouTube video URL, trying
+// oEmbed first and falling back to the Data API v3 when an API key is
+// configured.
+func (h *YouTubeHandler) fetchVideo(ctx context.Context, u *url.URL) (*metadata.Metadata, error) {
+	rawURL := u.String()
+	if meta, err := h.fetchOEmbed(ctx, rawURL); err == nil {
+		return meta, nil
+	}
+	if h.APIKey == "" {
+		return nil, fmt.Errorf("youtube: oembed failed and no API key configured")
+	}
+	videoID := extractYouTubeID(u)
+	return h.fetchDataAPI(ctx, rawURL, videoID)
+}
+
+// extractYouTubeID pulls the video ID from the URL.
+func extractYouTubeID(u *url.URL) string {
+	if strings.Contains(u.Host, "youtu.be") {
+		return strings.TrimPrefix(u.Path, "/")
+	}
+	return u.Query().Get("v")
+}
+
+func (h *YouTubeHandler) fetchOEmbed(ctx context.Context, videoURL string) (*metadata.Metadata, error) {
+	oeURL := fmt.Sprintf("%s?url=%s&format=json", h.oEmbedURL, url.QueryEscape(videoURL))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, oeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusErrorf(resp.StatusCode, "youtube: oembed error: status %d", resp.StatusCode)
+	}
+
+	var oe oEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&oe); err != nil {
+		return nil, err
+	}
+
+	return &metadata.Metadata{
+		Type:        "video",
+		URL:         videoURL,
+		PageName:    oe.Provider,
+		SiteName:    oe.Provider,
+		Title:       oe.Title,
+		Description: oe.AuthorName,
+		Author:      oe.AuthorName,
+		Images:      []metadata.Image{{URL: oe.ThumbnailURL}},
+	}, nil
+}
+
+func (h *YouTubeHandler) fetchDataAPI(ctx context.Context, videoURL, videoID string) (*metadata.Metadata, error) {
+	apiURL := fmt.Sprintf("%s?part=snippet,contentDetails&id=%s&key=%s", h.dataAPIURL, videoID, h.APIKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusErrorf(resp.StatusCode, "youtube: data API error: status %d", resp.StatusCode)
+	}
+
+	var data youtubeAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	if len(data.Items) == 0 {
+		return nil, fmt.Errorf("youtube: no video found for ID %s", videoID)
+	}
+
+	item := data.Items[0]
+	snip := item.Snippet
+	thumb := snip.Thumbnails["high"].URL
+
+	duration, err := parseISO8601Duration(item.ContentDetails.Duration)
+	if err != nil {
+		// Don't fail the whole lookup over a malformed duration; just omit it.
+		duration = 0
+	}
+
+	var publishedAt *time.Time
+	if !snip.PublishedAt.IsZero() {
+		publishedAt = &snip.PublishedAt
+	}
+
+	return &metadata.Metadata{
+		Type:        "video",
+		URL:         videoURL,
+		PageName:    snip.ChannelTitle,
+		SiteName:    "YouTube",
+		Title:       snip.Title,
+		Description: snip.Description,
+		Author:      snip.ChannelTitle,
+		PublishedAt: publishedAt,
+		Duration:    duration,
+		Images:      []metadata.Image{{URL: thumb}},
+	}, nil
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// youtubeChannelsResponse models JSON from the YouTube Data API v3's
+// channels endpoint.
+type youtubeChannelsResponse struct {
+	Items []struct {
+		Snippet struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+			CustomURL   string `json:"customUrl"`
+			Thumbnails  map[string]struct {
+				URL string `json:"url"`
+			} `json:"thumbnails"`
+		} `json:"snippet"`
+		Statistics struct {
+			SubscriberCount string `json:"subscriberCount"`
+		} `json:"statistics"`
+	} `json:"items"`
+}
+
+// fetchChannel resolves metadata for a /channel/{id}, /user/{name} or
+// /@{handle} URL, via the channels endpoint's id/forUsername/forHandle
+// lookup parameters respectively.
+func (h *YouTubeHandler) fetchChannel(ctx context.Context, u *url.URL) (*metadata.ChannelMetadata, error) {
+	if h.APIKey == "" {
+		return nil, fmt.Errorf("youtube: channel metadata requires an API key")
+	}
+
+	query, err := channelLookupQuery(u)
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := fmt.Sprintf("%s?part=snippet,statistics&%s&key=%s", h.channelsURL, query, h.APIKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusErrorf(resp.StatusCode, "youtube: channels API error: status %d", resp.StatusCode)
+	}
+
+	var data youtubeChannelsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	if len(data.Items) == 0 {
+		return nil, fmt.Errorf("youtube: no channel found for %s", u)
+	}
+
+	item := data.Items[0]
+	subCount, _ := strconv.ParseInt(item.Statistics.SubscriberCount, 10, 64)
+
+	return &metadata.ChannelMetadata{
+		Type:            "channel",
+		URL:             u.String(),
+		Title:           item.Snippet.Title,
+		Description:     item.Snippet.Description,
+		CustomURL:       item.Snippet.CustomURL,
+		SubscriberCount: subCount,
+		Avatar:          item.Snippet.Thumbnails["high"].URL,
+	}, nil
+}
+
+// channelLookupQuery maps a channel URL onto the channels.list query
+// parameter needed to resolve it: id, forUsername, or forHandle.
+func channelLookupQuery(u *url.URL) (string, error) {
+	path := strings.TrimPrefix(u.Path, "/")
+	switch {
+	case strings.HasPrefix(path, "channel/"):
+		return "id=" + strings.TrimPrefix(path, "channel/"), nil
+	case strings.HasPrefix(path, "user/"):
+		return "forUsername=" + strings.TrimPrefix(path, "user/"), nil
+	case strings.HasPrefix(path, "@"):
+		return "forHandle=" + path, nil
+	default:
+		return "", fmt.Errorf("youtube: unrecognized channel url %s", u)
+	}
+}
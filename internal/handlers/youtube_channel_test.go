@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+func TestYouTubeHandler_Matches_Channel(t *testing.T) {
+	h := NewYouTubeHandler("")
+	cases := []string{
+		"https://www.youtube.com/channel/UC123",
+		"https://www.youtube.com/user/someuser",
+		"https://www.youtube.com/@somehandle",
+	}
+	for _, raw := range cases {
+		u, _ := url.Parse(raw)
+		if !h.Matches(u) {
+			t.Errorf("Matches(%q) = false, want true", raw)
+		}
+	}
+}
+
+func TestYouTubeHandler_Fetch_Channel(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		serveFixture(t, w, "testdata/youtube_channel.json")
+	}))
+	defer srv.Close()
+
+	h := NewYouTubeHandler("test-key")
+	h.channelsURL = srv.URL
+
+	u, _ := url.Parse("https://www.youtube.com/@examplechannel")
+	got, err := h.Fetch(context.Background(), u)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	ch := got.(*metadata.ChannelMetadata)
+	if ch.Title != "Example Channel" {
+		t.Errorf("Title = %q", ch.Title)
+	}
+	if ch.SubscriberCount != 12345 {
+		t.Errorf("SubscriberCount = %d, want 12345", ch.SubscriberCount)
+	}
+	if !strings.Contains(gotQuery, "forHandle=@examplechannel") {
+		t.Errorf("query %q did not request forHandle lookup", gotQuery)
+	}
+}
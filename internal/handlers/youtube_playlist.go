@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// youtubePlaylistsResponse models JSON from the YouTube Data API v3's
+// playlists endpoint.
+type youtubePlaylistsResponse struct {
+	Items []struct {
+		Snippet struct {
+			Title        string `json:"title"`
+			ChannelTitle string `json:"channelTitle"`
+		} `json:"snippet"`
+		ContentDetails struct {
+			ItemCount int `json:"itemCount"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+// youtubePlaylistItemsResponse models JSON from the YouTube Data API v3's
+// playlistItems endpoint.
+type youtubePlaylistItemsResponse struct {
+	Items []struct {
+		Snippet struct {
+			Title      string `json:"title"`
+			ResourceID struct {
+				VideoID string `json:"videoId"`
+			} `json:"resourceId"`
+			Thumbnails map[string]struct {
+				URL string `json:"url"`
+			} `json:"thumbnails"`
+		} `json:"snippet"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// youtubePlaylistItemsPageSize is the maxResults used per playlistItems
+// page, the API's own maximum.
+const youtubePlaylistItemsPageSize = 50
+
+// fetchPlaylistSummary returns playlist metadata with a single page of
+// items, for the general /metadata endpoint.
+func (h *YouTubeHandler) fetchPlaylistSummary(ctx context.Context, playlistID string) (*metadata.PlaylistMetadata, error) {
+	return h.fetchPlaylist(ctx, playlistID, false)
+}
+
+// FetchPlaylistFull returns playlist metadata with every item, paginating
+// through playlistItems until exhausted. Intended for callers like the
+// dedicated /metadata/playlist endpoint that want the whole list.
+func (h *YouTubeHandler) FetchPlaylistFull(ctx context.Context, playlistID string) (*metadata.PlaylistMetadata, error) {
+	return h.fetchPlaylist(ctx, playlistID, true)
+}
+
+func (h *YouTubeHandler) fetchPlaylist(ctx context.Context, playlistID string, allPages bool) (*metadata.PlaylistMetadata, error) {
+	if h.APIKey == "" {
+		return nil, fmt.Errorf("youtube: playlist metadata requires an API key")
+	}
+
+	title, channel, itemCount, err := h.fetchPlaylistInfo(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	pl := &metadata.PlaylistMetadata{
+		Type:      "playlist",
+		URL:       fmt.Sprintf("https://www.youtube.com/playlist?list=%s", playlistID),
+		Title:     title,
+		Channel:   channel,
+		ItemCount: itemCount,
+	}
+
+	pageToken := ""
+	for {
+		items, next, err := h.fetchPlaylistItemsPage(ctx, playlistID, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		pl.Items = append(pl.Items, items...)
+		if !allPages || next == "" {
+			pl.NextPageToken = next
+			break
+		}
+		pageToken = next
+	}
+
+	return pl, nil
+}
+
+func (h *YouTubeHandler) fetchPlaylistInfo(ctx context.Context, playlistID string) (title, channel string, itemCount int, err error) {
+	apiURL := fmt.Sprintf("%s?part=snippet,contentDetails&id=%s&key=%s", h.playlistsURL, playlistID, h.APIKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return "", "", 0, err
+	}
+	resp, err := h.HTTPClient.Do(req)
+	if err != nil {
+		return "", "", 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", 0, statusErrorf(resp.StatusCode, "youtube: playlists API error: status %d", resp.StatusCode)
+	}
+
+	var data youtubePlaylistsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return "", "", 0, err
+	}
+	if len(data.Items) == 0 {
+		return "", "", 0, fmt.Errorf("youtube: no playlist found for ID %s", playlistID)
+	}
+
+	item := data.Items[0]
+	return item.Snippet.Title, item.Snippet.ChannelTitle, item.ContentDetails.ItemCount, nil
+}
+
+func (h *YouTubeHandler) fetchPlaylistItemsPage(ctx context.Context, playlistID, pageToken string) ([]metadata.PlaylistItem, string, error) {
+	apiURL := fmt.Sprintf("%s?part=snippet&playlistId=%s&maxResults=%d&key=%s",
+		h.playlistItemsURL, playlistID, youtubePlaylistItemsPageSize, h.APIKey)
+	if pageToken != "" {
+		apiURL += "&pageToken=" + pageToken
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := h.HTTPClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", statusErrorf(resp.StatusCode, "youtube: playlistItems API error: status %d", resp.StatusCode)
+	}
+
+	var data youtubePlaylistItemsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, "", err
+	}
+
+	items := make([]metadata.PlaylistItem, 0, len(data.Items))
+	for _, it := range data.Items {
+		items = append(items, metadata.PlaylistItem{
+			VideoID:   it.Snippet.ResourceID.VideoID,
+			Title:     it.Snippet.Title,
+			Thumbnail: it.Snippet.Thumbnails["default"].URL,
+		})
+	}
+	return items, data.NextPageToken, nil
+}
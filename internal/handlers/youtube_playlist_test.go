@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+func TestYouTubeHandler_Matches_Playlist(t *testing.T) {
+	h := NewYouTubeHandler("")
+	u, _ := url.Parse("https://www.youtube.com/playlist?list=PL12345")
+	if !h.Matches(u) {
+		t.Error("Matches(playlist url) = false, want true")
+	}
+}
+
+func TestYouTubeHandler_Fetch_PlaylistSummary(t *testing.T) {
+	infoSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveFixture(t, w, "testdata/youtube_playlist_info.json")
+	}))
+	defer infoSrv.Close()
+
+	itemsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageToken") == "PAGE2" {
+			serveFixture(t, w, "testdata/youtube_playlist_items_page2.json")
+			return
+		}
+		serveFixture(t, w, "testdata/youtube_playlist_items_page1.json")
+	}))
+	defer itemsSrv.Close()
+
+	h := NewYouTubeHandler("test-key")
+	h.playlistsURL = infoSrv.URL
+	h.playlistItemsURL = itemsSrv.URL
+
+	u, _ := url.Parse("https://www.youtube.com/playlist?list=PL12345")
+	got, err := h.Fetch(context.Background(), u)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	pl := got.(*metadata.PlaylistMetadata)
+	if pl.Title != "Best of 2024" || pl.ItemCount != 3 {
+		t.Errorf("got %+v", pl)
+	}
+	if len(pl.Items) != 2 {
+		t.Errorf("Items = %d, want 2 (single page only)", len(pl.Items))
+	}
+	if pl.NextPageToken != "PAGE2" {
+		t.Errorf("NextPageToken = %q, want PAGE2", pl.NextPageToken)
+	}
+}
+
+func TestYouTubeHandler_FetchPlaylistFull_Paginates(t *testing.T) {
+	infoSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveFixture(t, w, "testdata/youtube_playlist_info.json")
+	}))
+	defer infoSrv.Close()
+
+	itemsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageToken") == "PAGE2" {
+			serveFixture(t, w, "testdata/youtube_playlist_items_page2.json")
+			return
+		}
+		serveFixture(t, w, "testdata/youtube_playlist_items_page1.json")
+	}))
+	defer itemsSrv.Close()
+
+	h := NewYouTubeHandler("test-key")
+	h.playlistsURL = infoSrv.URL
+	h.playlistItemsURL = itemsSrv.URL
+
+	pl, err := h.FetchPlaylistFull(context.Background(), "PL12345")
+	if err != nil {
+		t.Fatalf("FetchPlaylistFull: %v", err)
+	}
+	if len(pl.Items) != 3 {
+		t.Errorf("Items = %d, want 3 across both pages", len(pl.Items))
+	}
+	if pl.NextPageToken != "" {
+		t.Errorf("NextPageToken = %q, want empty once exhausted", pl.NextPageToken)
+	}
+}
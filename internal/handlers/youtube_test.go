@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+func TestYouTubeHandler_Matches(t *testing.T) {
+	h := NewYouTubeHandler("")
+	cases := []struct {
+		rawURL string
+		want   bool
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", true},
+		{"https://youtu.be/dQw4w9WgXcQ", true},
+		{"https://vimeo.com/12345", false},
+	}
+	for _, tc := range cases {
+		u, err := url.Parse(tc.rawURL)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.rawURL, err)
+		}
+		if got := h.Matches(u); got != tc.want {
+			t.Errorf("Matches(%q) = %v, want %v", tc.rawURL, got, tc.want)
+		}
+	}
+}
+
+func TestYouTubeHandler_Fetch_OEmbed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveFixture(t, w, "testdata/youtube_oembed.json")
+	}))
+	defer srv.Close()
+
+	h := NewYouTubeHandler("")
+	h.oEmbedURL = srv.URL
+
+	u, _ := url.Parse("https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+	got, err := h.Fetch(context.Background(), u)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	meta := got.(*metadata.Metadata)
+	if meta.Title != "Rick Astley - Never Gonna Give You Up" {
+		t.Errorf("Title = %q", meta.Title)
+	}
+	if len(meta.Images) != 1 {
+		t.Errorf("Images = %v, want 1 entry", meta.Images)
+	}
+}
+
+func TestYouTubeHandler_Fetch_FallsBackToDataAPI(t *testing.T) {
+	oeSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer oeSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveFixture(t, w, "testdata/youtube_dataapi.json")
+	}))
+	defer apiSrv.Close()
+
+	h := NewYouTubeHandler("test-key")
+	h.oEmbedURL = oeSrv.URL
+	h.dataAPIURL = apiSrv.URL
+
+	u, _ := url.Parse("https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+	got, err := h.Fetch(context.Background(), u)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	meta := got.(*metadata.Metadata)
+	if meta.URL != u.String() {
+		t.Errorf("URL = %q, want %q", meta.URL, u.String())
+	}
+	if meta.PageName != "Rick Astley" {
+		t.Errorf("PageName = %q", meta.PageName)
+	}
+	if meta.Duration != 3*time.Minute+33*time.Second {
+		t.Errorf("Duration = %v", meta.Duration)
+	}
+	if meta.PublishedAt == nil || meta.PublishedAt.IsZero() {
+		t.Error("PublishedAt = nil or zero, want parsed timestamp")
+	}
+}
+
+func serveFixture(t *testing.T, w http.ResponseWriter, path string) {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", path, err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(b); err != nil {
+		t.Fatalf("write fixture %s: %v", path, err)
+	}
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/url"
+	"strings"
+)
+
+// youtubeURLKind classifies what sort of resource a YouTube URL refers to.
+type youtubeURLKind int
+
+const (
+	youtubeKindVideo youtubeURLKind = iota
+	youtubeKindPlaylist
+	youtubeKindChannel
+)
+
+// classifyYouTubeURL reports whether u points at a video, a playlist
+// (/playlist?list=...), or a channel (/channel/..., /user/..., /@handle).
+func classifyYouTubeURL(u *url.URL) youtubeURLKind {
+	if u.Path == "/playlist" && u.Query().Get("list") != "" {
+		return youtubeKindPlaylist
+	}
+	path := strings.TrimPrefix(u.Path, "/")
+	if strings.HasPrefix(path, "channel/") || strings.HasPrefix(path, "user/") || strings.HasPrefix(path, "@") {
+		return youtubeKindChannel
+	}
+	return youtubeKindVideo
+}
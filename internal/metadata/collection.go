@@ -0,0 +1,32 @@
+package metadata
+
+// PlaylistMetadata describes a video playlist (e.g. a YouTube playlist),
+// returned from /metadata with Type == "playlist".
+type PlaylistMetadata struct {
+	Type          string         `json:"type"`
+	URL           string         `json:"url"`
+	Title         string         `json:"title"`
+	Channel       string         `json:"channel"`
+	ItemCount     int            `json:"item_count"`
+	Items         []PlaylistItem `json:"items"`
+	NextPageToken string         `json:"next_page_token,omitempty"`
+}
+
+// PlaylistItem is a single video within a PlaylistMetadata.
+type PlaylistItem struct {
+	VideoID   string `json:"video_id"`
+	Title     string `json:"title"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+// ChannelMetadata describes a channel (e.g. a YouTube channel), returned
+// from /metadata with Type == "channel".
+type ChannelMetadata struct {
+	Type            string `json:"type"`
+	URL             string `json:"url"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	CustomURL       string `json:"custom_url,omitempty"`
+	SubscriberCount int64  `json:"subscriber_count,omitempty"`
+	Avatar          string `json:"avatar,omitempty"`
+}
@@ -0,0 +1,60 @@
+// Package metadata defines the response shapes returned by metalink's
+// handlers and generic scraper.
+package metadata
+
+import "time"
+
+// Metadata represents the scraped metadata from a webpage or media provider.
+// Type discriminates it from the other response shapes (PlaylistMetadata,
+// ChannelMetadata) a LinkHandler can return from the same /metadata
+// endpoint, e.g. "page", "video", "tweet".
+type Metadata struct {
+	Type        string     `json:"type"`
+	URL         string     `json:"url"`
+	PageName    string     `json:"page_name"`
+	SiteName    string     `json:"site_name,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Author      string     `json:"author,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Duration is the length of the underlying media, if any (e.g. a
+	// video or audio track). It's zero for plain web pages.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	Keywords []string `json:"keywords,omitempty"`
+	Language string   `json:"language,omitempty"`
+	Favicon  string   `json:"favicon,omitempty"`
+
+	Images       []Image     `json:"images"`
+	PrimaryImage *Image      `json:"primary_image,omitempty"`
+	Media        []MediaItem `json:"media,omitempty"`
+
+	// ContentType is the Content-Type of the HTTP response the generic
+	// fetcher received, and ContentLength the number of body bytes it read
+	// (capped at the fetcher's max body size). They're always populated by the generic
+	// scraper; other fields are left zero when the response wasn't HTML
+	// and so couldn't be parsed further.
+	ContentType   string `json:"content_type,omitempty"`
+	ContentLength int64  `json:"content_length,omitempty"`
+}
+
+// MediaItem is a single piece of structured media (video, audio, image)
+// associated with a page, carrying whatever dimensions were discoverable.
+type MediaItem struct {
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// Image is a single candidate image for a page or media item, with
+// whatever dimensions and alt text were discoverable so a client can pick
+// the best one without re-fetching every candidate itself.
+type Image struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Alt    string `json:"alt,omitempty"`
+}
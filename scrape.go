@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/tomoconnor/metalink/internal/fetch"
+	"github.com/tomoconnor/metalink/internal/metadata"
+)
+
+// fetchGenericMetadata scrapes OG/HTML metadata for pages with no
+// dedicated LinkHandler, using fetcher's SSRF-guarded client. Non-HTML
+// responses are not parsed; a minimal Metadata carrying ContentType and
+// ContentLength is returned instead.
+func fetchGenericMetadata(ctx context.Context, fetcher *fetch.Fetcher, parsedURL *url.URL) (*metadata.Metadata, error) {
+	result, err := fetcher.Fetch(ctx, parsedURL.String())
+	if err != nil {
+		return nil, err
+	}
+	if result.StatusCode < 200 || result.StatusCode >= 300 {
+		return nil, statusErrorf(result.StatusCode, "fetch: upstream returned status %d", result.StatusCode)
+	}
+
+	if !isHTMLContentType(result.ContentType) {
+		return &metadata.Metadata{
+			Type:          "page",
+			URL:           parsedURL.String(),
+			ContentType:   result.ContentType,
+			ContentLength: result.ContentLength,
+		}, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(result.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	ld := parseJSONLD(doc)
+
+	meta := &metadata.Metadata{
+		Type:          "page",
+		URL:           parsedURL.String(),
+		ContentType:   result.ContentType,
+		ContentLength: result.ContentLength,
+	}
+	meta.SiteName = getFirstContent(doc, "meta[property='og:site_name']")
+	meta.PageName = meta.SiteName
+	if meta.PageName == "" {
+		meta.PageName = parsedURL.Host
+	}
+	meta.Title = getFirstContent(doc, "meta[property='og:title']")
+	if meta.Title == "" {
+		meta.Title = strings.TrimSpace(doc.Find("title").Text())
+	}
+	meta.Description = getFirstContent(doc, "meta[property='og:description']")
+	if meta.Description == "" {
+		meta.Description = getFirstContent(doc, "meta[name='description']")
+	}
+
+	meta.Author = getFirstContent(doc, "meta[name='author']")
+	if meta.Author == "" {
+		meta.Author = getFirstContent(doc, "meta[property='article:author']")
+	}
+	if meta.Author == "" {
+		meta.Author = ld.Author
+	}
+
+	if published := getFirstContent(doc, "meta[property='article:published_time']"); published != "" {
+		if t, err := time.Parse(time.RFC3339, published); err == nil {
+			meta.PublishedAt = &t
+		}
+	}
+	if meta.PublishedAt == nil && ld.DatePublished != "" {
+		if t, err := time.Parse(time.RFC3339, ld.DatePublished); err == nil {
+			meta.PublishedAt = &t
+		}
+	}
+
+	if keywords := getFirstContent(doc, "meta[name='keywords']"); keywords != "" {
+		meta.Keywords = splitAndTrim(keywords, ",")
+	} else if len(ld.Keywords) > 0 {
+		meta.Keywords = ld.Keywords
+	}
+
+	meta.Language, _ = doc.Find("html").Attr("lang")
+
+	if favicon, ok := doc.Find("link[rel='icon']").Attr("href"); ok {
+		meta.Favicon = resolveURL(parsedURL, favicon)
+	} else if favicon, ok := doc.Find("link[rel='shortcut icon']").Attr("href"); ok {
+		meta.Favicon = resolveURL(parsedURL, favicon)
+	}
+
+	ogImages := collectOGImages(doc, parsedURL)
+	meta.Images = ogImages
+	if len(meta.Images) == 0 {
+		meta.Images = collectFallbackImages(doc, parsedURL)
+	}
+	if parsedURL.Query().Get("probe") == "1" {
+		probeImageDimensions(ctx, fetcher, meta.Images)
+	}
+	meta.PrimaryImage = selectPrimaryImage(meta.Images, len(ogImages))
+
+	if videoURL := getFirstContent(doc, "meta[property='og:video:url']"); videoURL != "" {
+		item := metadata.MediaItem{
+			Type: "video",
+			URL:  resolveURL(parsedURL, videoURL),
+		}
+		item.Width = atoiOrZero(getFirstContent(doc, "meta[property='og:video:width']"))
+		item.Height = atoiOrZero(getFirstContent(doc, "meta[property='og:video:height']"))
+		meta.Media = append(meta.Media, item)
+	}
+
+	return meta, nil
+}
+
+// isHTMLContentType reports whether a Content-Type header value looks
+// like HTML, ignoring any charset or other parameters.
+func isHTMLContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// getFirstContent finds the first meta tag by selector and returns its content attribute
+func getFirstContent(doc *goquery.Document, selector string) string {
+	sel := doc.Find(selector)
+	if sel != nil {
+		if content, exists := sel.First().Attr("content"); exists {
+			return strings.TrimSpace(content)
+		}
+	}
+	return ""
+}
+
+// resolveURL resolves ref against base, returning ref unchanged if it
+// cannot be parsed.
+func resolveURL(base *url.URL, ref string) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// splitAndTrim splits s on sep and trims whitespace from each field,
+// dropping empty results.
+func splitAndTrim(s, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// atoiOrZero parses s as an int, returning 0 if it isn't one.
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// jsonLD holds the subset of schema.org JSON-LD fields metalink cares
+// about, as found in a page's <script type="application/ld+json"> blocks.
+type jsonLD struct {
+	Author        string
+	DatePublished string
+	Keywords      []string
+}
+
+// jsonLDDoc models the shapes JSON-LD authors actually emit for the
+// fields we read: Author can be a plain string or a Person/Organization
+// object, and keywords can be a comma-separated string or an array.
+type jsonLDDoc struct {
+	DatePublished string          `json:"datePublished"`
+	RawAuthor     json.RawMessage `json:"author"`
+	RawKeywords   json.RawMessage `json:"keywords"`
+}
+
+// parseJSONLD scans a document's ld+json blocks and returns the first
+// usable set of fields it finds. It's best-effort: malformed blocks are
+// skipped rather than treated as errors.
+func parseJSONLD(doc *goquery.Document) jsonLD {
+	var result jsonLD
+	doc.Find("script[type='application/ld+json']").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var raw jsonLDDoc
+		if err := json.Unmarshal([]byte(s.Text()), &raw); err != nil {
+			return true // keep looking
+		}
+
+		result.DatePublished = raw.DatePublished
+
+		if len(raw.RawAuthor) > 0 {
+			var name string
+			if err := json.Unmarshal(raw.RawAuthor, &name); err == nil {
+				result.Author = name
+			} else {
+				var obj struct {
+					Name string `json:"name"`
+				}
+				if err := json.Unmarshal(raw.RawAuthor, &obj); err == nil {
+					result.Author = obj.Name
+				}
+			}
+		}
+
+		if len(raw.RawKeywords) > 0 {
+			var list []string
+			if err := json.Unmarshal(raw.RawKeywords, &list); err == nil {
+				result.Keywords = list
+			} else {
+				var csv string
+				if err := json.Unmarshal(raw.RawKeywords, &csv); err == nil {
+					result.Keywords = splitAndTrim(csv, ",")
+				}
+			}
+		}
+
+		return result.Author == "" && result.DatePublished == "" && len(result.Keywords) == 0
+	})
+	return result
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/tomoconnor/metalink/internal/fetch"
+)
+
+func TestParseJSONLD_AuthorAsBareString(t *testing.T) {
+	html := `<html><head><script type="application/ld+json">
+		{"author": "Jane Doe", "datePublished": "2024-01-02T03:04:05Z"}
+	</script></head></html>`
+	doc := mustParseDoc(t, html)
+
+	ld := parseJSONLD(doc)
+	if ld.Author != "Jane Doe" {
+		t.Errorf("Author = %q, want %q", ld.Author, "Jane Doe")
+	}
+	if ld.DatePublished != "2024-01-02T03:04:05Z" {
+		t.Errorf("DatePublished = %q", ld.DatePublished)
+	}
+}
+
+func TestParseJSONLD_AuthorAsObject(t *testing.T) {
+	html := `<html><head><script type="application/ld+json">
+		{"author": {"name": "Jane Doe"}}
+	</script></head></html>`
+	doc := mustParseDoc(t, html)
+
+	ld := parseJSONLD(doc)
+	if ld.Author != "Jane Doe" {
+		t.Errorf("Author = %q, want %q", ld.Author, "Jane Doe")
+	}
+}
+
+func TestParseJSONLD_KeywordsAsArray(t *testing.T) {
+	html := `<html><head><script type="application/ld+json">
+		{"keywords": ["go", "metadata"]}
+	</script></head></html>`
+	doc := mustParseDoc(t, html)
+
+	ld := parseJSONLD(doc)
+	if len(ld.Keywords) != 2 || ld.Keywords[0] != "go" || ld.Keywords[1] != "metadata" {
+		t.Errorf("Keywords = %v, want [go metadata]", ld.Keywords)
+	}
+}
+
+func TestParseJSONLD_KeywordsAsCSVString(t *testing.T) {
+	html := `<html><head><script type="application/ld+json">
+		{"keywords": "go, metadata"}
+	</script></head></html>`
+	doc := mustParseDoc(t, html)
+
+	ld := parseJSONLD(doc)
+	if len(ld.Keywords) != 2 || ld.Keywords[0] != "go" || ld.Keywords[1] != "metadata" {
+		t.Errorf("Keywords = %v, want [go metadata]", ld.Keywords)
+	}
+}
+
+func TestParseJSONLD_SkipsMalformedBlockAndKeepsLooking(t *testing.T) {
+	html := `<html><head>
+		<script type="application/ld+json">not json</script>
+		<script type="application/ld+json">{"author": "Jane Doe"}</script>
+	</head></html>`
+	doc := mustParseDoc(t, html)
+
+	ld := parseJSONLD(doc)
+	if ld.Author != "Jane Doe" {
+		t.Errorf("Author = %q, want %q (should skip the malformed block)", ld.Author, "Jane Doe")
+	}
+}
+
+func newTestFetcher() *fetch.Fetcher {
+	return fetch.NewFetcher(fetch.WithAllowedHosts("127.0.0.1"), fetch.WithRobotsCheck(false))
+}
+
+func TestFetchGenericMetadata_PrefersArticlePublishedTimeOverJSONLD(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head>
+			<meta property="article:published_time" content="2024-05-01T00:00:00Z">
+			<script type="application/ld+json">{"datePublished": "2020-01-01T00:00:00Z"}</script>
+		</head></html>`))
+	}))
+	defer srv.Close()
+
+	parsedURL, _ := url.Parse(srv.URL)
+	meta, err := fetchGenericMetadata(context.Background(), newTestFetcher(), parsedURL)
+	if err != nil {
+		t.Fatalf("fetchGenericMetadata: %v", err)
+	}
+	if meta.PublishedAt == nil || meta.PublishedAt.Year() != 2024 {
+		t.Errorf("PublishedAt = %v, want 2024-05-01 from article:published_time", meta.PublishedAt)
+	}
+}
+
+func TestFetchGenericMetadata_FallsBackToJSONLDDate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head>
+			<script type="application/ld+json">{"datePublished": "2020-01-01T00:00:00Z"}</script>
+		</head></html>`))
+	}))
+	defer srv.Close()
+
+	parsedURL, _ := url.Parse(srv.URL)
+	meta, err := fetchGenericMetadata(context.Background(), newTestFetcher(), parsedURL)
+	if err != nil {
+		t.Fatalf("fetchGenericMetadata: %v", err)
+	}
+	if meta.PublishedAt == nil || meta.PublishedAt.Year() != 2020 {
+		t.Errorf("PublishedAt = %v, want 2020-01-01 from JSON-LD", meta.PublishedAt)
+	}
+}
+
+func TestFetchGenericMetadata_UnparsableDateLeavesPublishedAtNil(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head>
+			<meta property="article:published_time" content="not-a-date">
+		</head></html>`))
+	}))
+	defer srv.Close()
+
+	parsedURL, _ := url.Parse(srv.URL)
+	meta, err := fetchGenericMetadata(context.Background(), newTestFetcher(), parsedURL)
+	if err != nil {
+		t.Fatalf("fetchGenericMetadata: %v", err)
+	}
+	if meta.PublishedAt != nil {
+		t.Errorf("PublishedAt = %v, want nil for an unparsable date", meta.PublishedAt)
+	}
+}